@@ -0,0 +1,14 @@
+package lea
+
+import "crypto/cipher"
+
+// NewGCM wraps key in a cipher.AEAD implementing LEA-GCM as specified by
+// TTAK.KO-12.0223. It is a thin convenience wrapper around
+// crypto/cipher.NewGCM, since Cipher already satisfies cipher.Block.
+func NewGCM(key []byte) (cipher.AEAD, error) {
+	block, err := NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,1019 @@
+//go:build (amd64 || arm64) && !purego
+
+package lea
+
+import "encoding/binary"
+
+// rolAsm and rorAsm are implemented in block_amd64.s / block_arm64.s
+// using the native ROL/ROR instruction instead of the shift-or sequence
+// the portable (block_generic.go) path uses.
+func rolAsm(x, n uint32) uint32
+func rorAsm(x, n uint32) uint32
+
+func rolFast(w word, r uint) word {
+	return word(rolAsm(uint32(w), uint32(r)))
+}
+
+func rorFast(w word, r uint) word {
+	return word(rorAsm(uint32(w), uint32(r)))
+}
+
+// encryptBlockGo dispatches to a fully unrolled, per-key-length variant
+// so the hot loop never re-slices the round-key array or re-derives
+// loop bounds; see block_generic.go for the portable fallback used on
+// other architectures and under the purego build tag.
+func encryptBlockGo(dst, src []byte, rk []word) {
+	switch len(rk) / 6 {
+	case 24:
+		encryptBlock128(dst, src, rk)
+	case 28:
+		encryptBlock192(dst, src, rk)
+	case 32:
+		encryptBlock256(dst, src, rk)
+	default:
+		panic("lea: invalid expanded round key length")
+	}
+}
+
+// decryptBlockGo is the decryption counterpart of encryptBlockGo.
+func decryptBlockGo(dst, src []byte, rk []word) {
+	switch len(rk) / 6 {
+	case 24:
+		decryptBlock128(dst, src, rk)
+	case 28:
+		decryptBlock192(dst, src, rk)
+	case 32:
+		decryptBlock256(dst, src, rk)
+	default:
+		panic("lea: invalid expanded round key length")
+	}
+}
+
+func loadState(src []byte) (x0, x1, x2, x3 word) {
+	x0 = word(binary.LittleEndian.Uint32(src[0:4]))
+	x1 = word(binary.LittleEndian.Uint32(src[4:8]))
+	x2 = word(binary.LittleEndian.Uint32(src[8:12]))
+	x3 = word(binary.LittleEndian.Uint32(src[12:16]))
+	return
+}
+
+func storeState(dst []byte, x0, x1, x2, x3 word) {
+	binary.LittleEndian.PutUint32(dst[0:4], uint32(x0))
+	binary.LittleEndian.PutUint32(dst[4:8], uint32(x1))
+	binary.LittleEndian.PutUint32(dst[8:12], uint32(x2))
+	binary.LittleEndian.PutUint32(dst[12:16], uint32(x3))
+}
+
+func encryptBlock128(dst, src []byte, rk []word) {
+	x0, x1, x2, x3 := loadState(src)
+	var t0, t1, t2 word
+	t0 = rolFast((x0^rk[0])+(x1^rk[1]), 9)
+	t1 = rorFast((x1^rk[2])+(x2^rk[3]), 5)
+	t2 = rorFast((x2^rk[4])+(x3^rk[5]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[6])+(x1^rk[7]), 9)
+	t1 = rorFast((x1^rk[8])+(x2^rk[9]), 5)
+	t2 = rorFast((x2^rk[10])+(x3^rk[11]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[12])+(x1^rk[13]), 9)
+	t1 = rorFast((x1^rk[14])+(x2^rk[15]), 5)
+	t2 = rorFast((x2^rk[16])+(x3^rk[17]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[18])+(x1^rk[19]), 9)
+	t1 = rorFast((x1^rk[20])+(x2^rk[21]), 5)
+	t2 = rorFast((x2^rk[22])+(x3^rk[23]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[24])+(x1^rk[25]), 9)
+	t1 = rorFast((x1^rk[26])+(x2^rk[27]), 5)
+	t2 = rorFast((x2^rk[28])+(x3^rk[29]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[30])+(x1^rk[31]), 9)
+	t1 = rorFast((x1^rk[32])+(x2^rk[33]), 5)
+	t2 = rorFast((x2^rk[34])+(x3^rk[35]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[36])+(x1^rk[37]), 9)
+	t1 = rorFast((x1^rk[38])+(x2^rk[39]), 5)
+	t2 = rorFast((x2^rk[40])+(x3^rk[41]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[42])+(x1^rk[43]), 9)
+	t1 = rorFast((x1^rk[44])+(x2^rk[45]), 5)
+	t2 = rorFast((x2^rk[46])+(x3^rk[47]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[48])+(x1^rk[49]), 9)
+	t1 = rorFast((x1^rk[50])+(x2^rk[51]), 5)
+	t2 = rorFast((x2^rk[52])+(x3^rk[53]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[54])+(x1^rk[55]), 9)
+	t1 = rorFast((x1^rk[56])+(x2^rk[57]), 5)
+	t2 = rorFast((x2^rk[58])+(x3^rk[59]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[60])+(x1^rk[61]), 9)
+	t1 = rorFast((x1^rk[62])+(x2^rk[63]), 5)
+	t2 = rorFast((x2^rk[64])+(x3^rk[65]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[66])+(x1^rk[67]), 9)
+	t1 = rorFast((x1^rk[68])+(x2^rk[69]), 5)
+	t2 = rorFast((x2^rk[70])+(x3^rk[71]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[72])+(x1^rk[73]), 9)
+	t1 = rorFast((x1^rk[74])+(x2^rk[75]), 5)
+	t2 = rorFast((x2^rk[76])+(x3^rk[77]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[78])+(x1^rk[79]), 9)
+	t1 = rorFast((x1^rk[80])+(x2^rk[81]), 5)
+	t2 = rorFast((x2^rk[82])+(x3^rk[83]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[84])+(x1^rk[85]), 9)
+	t1 = rorFast((x1^rk[86])+(x2^rk[87]), 5)
+	t2 = rorFast((x2^rk[88])+(x3^rk[89]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[90])+(x1^rk[91]), 9)
+	t1 = rorFast((x1^rk[92])+(x2^rk[93]), 5)
+	t2 = rorFast((x2^rk[94])+(x3^rk[95]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[96])+(x1^rk[97]), 9)
+	t1 = rorFast((x1^rk[98])+(x2^rk[99]), 5)
+	t2 = rorFast((x2^rk[100])+(x3^rk[101]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[102])+(x1^rk[103]), 9)
+	t1 = rorFast((x1^rk[104])+(x2^rk[105]), 5)
+	t2 = rorFast((x2^rk[106])+(x3^rk[107]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[108])+(x1^rk[109]), 9)
+	t1 = rorFast((x1^rk[110])+(x2^rk[111]), 5)
+	t2 = rorFast((x2^rk[112])+(x3^rk[113]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[114])+(x1^rk[115]), 9)
+	t1 = rorFast((x1^rk[116])+(x2^rk[117]), 5)
+	t2 = rorFast((x2^rk[118])+(x3^rk[119]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[120])+(x1^rk[121]), 9)
+	t1 = rorFast((x1^rk[122])+(x2^rk[123]), 5)
+	t2 = rorFast((x2^rk[124])+(x3^rk[125]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[126])+(x1^rk[127]), 9)
+	t1 = rorFast((x1^rk[128])+(x2^rk[129]), 5)
+	t2 = rorFast((x2^rk[130])+(x3^rk[131]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[132])+(x1^rk[133]), 9)
+	t1 = rorFast((x1^rk[134])+(x2^rk[135]), 5)
+	t2 = rorFast((x2^rk[136])+(x3^rk[137]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[138])+(x1^rk[139]), 9)
+	t1 = rorFast((x1^rk[140])+(x2^rk[141]), 5)
+	t2 = rorFast((x2^rk[142])+(x3^rk[143]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+	storeState(dst, x0, x1, x2, x3)
+}
+
+func decryptBlock128(dst, src []byte, rk []word) {
+	x0, x1, x2, x3 := loadState(src)
+	var t0, t1, t2, t3 word
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[0])) ^ rk[1]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[2])) ^ rk[3]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[4])) ^ rk[5]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[6])) ^ rk[7]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[8])) ^ rk[9]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[10])) ^ rk[11]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[12])) ^ rk[13]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[14])) ^ rk[15]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[16])) ^ rk[17]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[18])) ^ rk[19]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[20])) ^ rk[21]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[22])) ^ rk[23]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[24])) ^ rk[25]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[26])) ^ rk[27]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[28])) ^ rk[29]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[30])) ^ rk[31]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[32])) ^ rk[33]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[34])) ^ rk[35]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[36])) ^ rk[37]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[38])) ^ rk[39]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[40])) ^ rk[41]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[42])) ^ rk[43]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[44])) ^ rk[45]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[46])) ^ rk[47]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[48])) ^ rk[49]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[50])) ^ rk[51]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[52])) ^ rk[53]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[54])) ^ rk[55]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[56])) ^ rk[57]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[58])) ^ rk[59]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[60])) ^ rk[61]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[62])) ^ rk[63]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[64])) ^ rk[65]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[66])) ^ rk[67]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[68])) ^ rk[69]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[70])) ^ rk[71]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[72])) ^ rk[73]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[74])) ^ rk[75]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[76])) ^ rk[77]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[78])) ^ rk[79]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[80])) ^ rk[81]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[82])) ^ rk[83]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[84])) ^ rk[85]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[86])) ^ rk[87]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[88])) ^ rk[89]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[90])) ^ rk[91]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[92])) ^ rk[93]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[94])) ^ rk[95]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[96])) ^ rk[97]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[98])) ^ rk[99]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[100])) ^ rk[101]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[102])) ^ rk[103]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[104])) ^ rk[105]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[106])) ^ rk[107]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[108])) ^ rk[109]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[110])) ^ rk[111]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[112])) ^ rk[113]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[114])) ^ rk[115]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[116])) ^ rk[117]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[118])) ^ rk[119]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[120])) ^ rk[121]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[122])) ^ rk[123]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[124])) ^ rk[125]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[126])) ^ rk[127]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[128])) ^ rk[129]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[130])) ^ rk[131]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[132])) ^ rk[133]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[134])) ^ rk[135]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[136])) ^ rk[137]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[138])) ^ rk[139]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[140])) ^ rk[141]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[142])) ^ rk[143]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+	storeState(dst, x0, x1, x2, x3)
+}
+
+func encryptBlock192(dst, src []byte, rk []word) {
+	x0, x1, x2, x3 := loadState(src)
+	var t0, t1, t2 word
+	t0 = rolFast((x0^rk[0])+(x1^rk[1]), 9)
+	t1 = rorFast((x1^rk[2])+(x2^rk[3]), 5)
+	t2 = rorFast((x2^rk[4])+(x3^rk[5]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[6])+(x1^rk[7]), 9)
+	t1 = rorFast((x1^rk[8])+(x2^rk[9]), 5)
+	t2 = rorFast((x2^rk[10])+(x3^rk[11]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[12])+(x1^rk[13]), 9)
+	t1 = rorFast((x1^rk[14])+(x2^rk[15]), 5)
+	t2 = rorFast((x2^rk[16])+(x3^rk[17]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[18])+(x1^rk[19]), 9)
+	t1 = rorFast((x1^rk[20])+(x2^rk[21]), 5)
+	t2 = rorFast((x2^rk[22])+(x3^rk[23]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[24])+(x1^rk[25]), 9)
+	t1 = rorFast((x1^rk[26])+(x2^rk[27]), 5)
+	t2 = rorFast((x2^rk[28])+(x3^rk[29]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[30])+(x1^rk[31]), 9)
+	t1 = rorFast((x1^rk[32])+(x2^rk[33]), 5)
+	t2 = rorFast((x2^rk[34])+(x3^rk[35]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[36])+(x1^rk[37]), 9)
+	t1 = rorFast((x1^rk[38])+(x2^rk[39]), 5)
+	t2 = rorFast((x2^rk[40])+(x3^rk[41]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[42])+(x1^rk[43]), 9)
+	t1 = rorFast((x1^rk[44])+(x2^rk[45]), 5)
+	t2 = rorFast((x2^rk[46])+(x3^rk[47]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[48])+(x1^rk[49]), 9)
+	t1 = rorFast((x1^rk[50])+(x2^rk[51]), 5)
+	t2 = rorFast((x2^rk[52])+(x3^rk[53]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[54])+(x1^rk[55]), 9)
+	t1 = rorFast((x1^rk[56])+(x2^rk[57]), 5)
+	t2 = rorFast((x2^rk[58])+(x3^rk[59]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[60])+(x1^rk[61]), 9)
+	t1 = rorFast((x1^rk[62])+(x2^rk[63]), 5)
+	t2 = rorFast((x2^rk[64])+(x3^rk[65]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[66])+(x1^rk[67]), 9)
+	t1 = rorFast((x1^rk[68])+(x2^rk[69]), 5)
+	t2 = rorFast((x2^rk[70])+(x3^rk[71]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[72])+(x1^rk[73]), 9)
+	t1 = rorFast((x1^rk[74])+(x2^rk[75]), 5)
+	t2 = rorFast((x2^rk[76])+(x3^rk[77]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[78])+(x1^rk[79]), 9)
+	t1 = rorFast((x1^rk[80])+(x2^rk[81]), 5)
+	t2 = rorFast((x2^rk[82])+(x3^rk[83]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[84])+(x1^rk[85]), 9)
+	t1 = rorFast((x1^rk[86])+(x2^rk[87]), 5)
+	t2 = rorFast((x2^rk[88])+(x3^rk[89]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[90])+(x1^rk[91]), 9)
+	t1 = rorFast((x1^rk[92])+(x2^rk[93]), 5)
+	t2 = rorFast((x2^rk[94])+(x3^rk[95]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[96])+(x1^rk[97]), 9)
+	t1 = rorFast((x1^rk[98])+(x2^rk[99]), 5)
+	t2 = rorFast((x2^rk[100])+(x3^rk[101]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[102])+(x1^rk[103]), 9)
+	t1 = rorFast((x1^rk[104])+(x2^rk[105]), 5)
+	t2 = rorFast((x2^rk[106])+(x3^rk[107]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[108])+(x1^rk[109]), 9)
+	t1 = rorFast((x1^rk[110])+(x2^rk[111]), 5)
+	t2 = rorFast((x2^rk[112])+(x3^rk[113]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[114])+(x1^rk[115]), 9)
+	t1 = rorFast((x1^rk[116])+(x2^rk[117]), 5)
+	t2 = rorFast((x2^rk[118])+(x3^rk[119]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[120])+(x1^rk[121]), 9)
+	t1 = rorFast((x1^rk[122])+(x2^rk[123]), 5)
+	t2 = rorFast((x2^rk[124])+(x3^rk[125]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[126])+(x1^rk[127]), 9)
+	t1 = rorFast((x1^rk[128])+(x2^rk[129]), 5)
+	t2 = rorFast((x2^rk[130])+(x3^rk[131]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[132])+(x1^rk[133]), 9)
+	t1 = rorFast((x1^rk[134])+(x2^rk[135]), 5)
+	t2 = rorFast((x2^rk[136])+(x3^rk[137]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[138])+(x1^rk[139]), 9)
+	t1 = rorFast((x1^rk[140])+(x2^rk[141]), 5)
+	t2 = rorFast((x2^rk[142])+(x3^rk[143]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[144])+(x1^rk[145]), 9)
+	t1 = rorFast((x1^rk[146])+(x2^rk[147]), 5)
+	t2 = rorFast((x2^rk[148])+(x3^rk[149]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[150])+(x1^rk[151]), 9)
+	t1 = rorFast((x1^rk[152])+(x2^rk[153]), 5)
+	t2 = rorFast((x2^rk[154])+(x3^rk[155]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[156])+(x1^rk[157]), 9)
+	t1 = rorFast((x1^rk[158])+(x2^rk[159]), 5)
+	t2 = rorFast((x2^rk[160])+(x3^rk[161]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[162])+(x1^rk[163]), 9)
+	t1 = rorFast((x1^rk[164])+(x2^rk[165]), 5)
+	t2 = rorFast((x2^rk[166])+(x3^rk[167]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+	storeState(dst, x0, x1, x2, x3)
+}
+
+func decryptBlock192(dst, src []byte, rk []word) {
+	x0, x1, x2, x3 := loadState(src)
+	var t0, t1, t2, t3 word
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[0])) ^ rk[1]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[2])) ^ rk[3]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[4])) ^ rk[5]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[6])) ^ rk[7]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[8])) ^ rk[9]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[10])) ^ rk[11]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[12])) ^ rk[13]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[14])) ^ rk[15]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[16])) ^ rk[17]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[18])) ^ rk[19]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[20])) ^ rk[21]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[22])) ^ rk[23]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[24])) ^ rk[25]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[26])) ^ rk[27]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[28])) ^ rk[29]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[30])) ^ rk[31]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[32])) ^ rk[33]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[34])) ^ rk[35]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[36])) ^ rk[37]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[38])) ^ rk[39]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[40])) ^ rk[41]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[42])) ^ rk[43]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[44])) ^ rk[45]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[46])) ^ rk[47]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[48])) ^ rk[49]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[50])) ^ rk[51]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[52])) ^ rk[53]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[54])) ^ rk[55]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[56])) ^ rk[57]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[58])) ^ rk[59]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[60])) ^ rk[61]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[62])) ^ rk[63]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[64])) ^ rk[65]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[66])) ^ rk[67]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[68])) ^ rk[69]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[70])) ^ rk[71]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[72])) ^ rk[73]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[74])) ^ rk[75]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[76])) ^ rk[77]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[78])) ^ rk[79]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[80])) ^ rk[81]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[82])) ^ rk[83]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[84])) ^ rk[85]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[86])) ^ rk[87]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[88])) ^ rk[89]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[90])) ^ rk[91]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[92])) ^ rk[93]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[94])) ^ rk[95]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[96])) ^ rk[97]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[98])) ^ rk[99]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[100])) ^ rk[101]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[102])) ^ rk[103]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[104])) ^ rk[105]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[106])) ^ rk[107]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[108])) ^ rk[109]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[110])) ^ rk[111]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[112])) ^ rk[113]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[114])) ^ rk[115]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[116])) ^ rk[117]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[118])) ^ rk[119]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[120])) ^ rk[121]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[122])) ^ rk[123]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[124])) ^ rk[125]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[126])) ^ rk[127]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[128])) ^ rk[129]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[130])) ^ rk[131]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[132])) ^ rk[133]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[134])) ^ rk[135]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[136])) ^ rk[137]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[138])) ^ rk[139]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[140])) ^ rk[141]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[142])) ^ rk[143]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[144])) ^ rk[145]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[146])) ^ rk[147]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[148])) ^ rk[149]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[150])) ^ rk[151]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[152])) ^ rk[153]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[154])) ^ rk[155]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[156])) ^ rk[157]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[158])) ^ rk[159]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[160])) ^ rk[161]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[162])) ^ rk[163]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[164])) ^ rk[165]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[166])) ^ rk[167]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+	storeState(dst, x0, x1, x2, x3)
+}
+
+func encryptBlock256(dst, src []byte, rk []word) {
+	x0, x1, x2, x3 := loadState(src)
+	var t0, t1, t2 word
+	t0 = rolFast((x0^rk[0])+(x1^rk[1]), 9)
+	t1 = rorFast((x1^rk[2])+(x2^rk[3]), 5)
+	t2 = rorFast((x2^rk[4])+(x3^rk[5]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[6])+(x1^rk[7]), 9)
+	t1 = rorFast((x1^rk[8])+(x2^rk[9]), 5)
+	t2 = rorFast((x2^rk[10])+(x3^rk[11]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[12])+(x1^rk[13]), 9)
+	t1 = rorFast((x1^rk[14])+(x2^rk[15]), 5)
+	t2 = rorFast((x2^rk[16])+(x3^rk[17]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[18])+(x1^rk[19]), 9)
+	t1 = rorFast((x1^rk[20])+(x2^rk[21]), 5)
+	t2 = rorFast((x2^rk[22])+(x3^rk[23]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[24])+(x1^rk[25]), 9)
+	t1 = rorFast((x1^rk[26])+(x2^rk[27]), 5)
+	t2 = rorFast((x2^rk[28])+(x3^rk[29]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[30])+(x1^rk[31]), 9)
+	t1 = rorFast((x1^rk[32])+(x2^rk[33]), 5)
+	t2 = rorFast((x2^rk[34])+(x3^rk[35]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[36])+(x1^rk[37]), 9)
+	t1 = rorFast((x1^rk[38])+(x2^rk[39]), 5)
+	t2 = rorFast((x2^rk[40])+(x3^rk[41]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[42])+(x1^rk[43]), 9)
+	t1 = rorFast((x1^rk[44])+(x2^rk[45]), 5)
+	t2 = rorFast((x2^rk[46])+(x3^rk[47]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[48])+(x1^rk[49]), 9)
+	t1 = rorFast((x1^rk[50])+(x2^rk[51]), 5)
+	t2 = rorFast((x2^rk[52])+(x3^rk[53]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[54])+(x1^rk[55]), 9)
+	t1 = rorFast((x1^rk[56])+(x2^rk[57]), 5)
+	t2 = rorFast((x2^rk[58])+(x3^rk[59]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[60])+(x1^rk[61]), 9)
+	t1 = rorFast((x1^rk[62])+(x2^rk[63]), 5)
+	t2 = rorFast((x2^rk[64])+(x3^rk[65]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[66])+(x1^rk[67]), 9)
+	t1 = rorFast((x1^rk[68])+(x2^rk[69]), 5)
+	t2 = rorFast((x2^rk[70])+(x3^rk[71]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[72])+(x1^rk[73]), 9)
+	t1 = rorFast((x1^rk[74])+(x2^rk[75]), 5)
+	t2 = rorFast((x2^rk[76])+(x3^rk[77]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[78])+(x1^rk[79]), 9)
+	t1 = rorFast((x1^rk[80])+(x2^rk[81]), 5)
+	t2 = rorFast((x2^rk[82])+(x3^rk[83]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[84])+(x1^rk[85]), 9)
+	t1 = rorFast((x1^rk[86])+(x2^rk[87]), 5)
+	t2 = rorFast((x2^rk[88])+(x3^rk[89]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[90])+(x1^rk[91]), 9)
+	t1 = rorFast((x1^rk[92])+(x2^rk[93]), 5)
+	t2 = rorFast((x2^rk[94])+(x3^rk[95]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[96])+(x1^rk[97]), 9)
+	t1 = rorFast((x1^rk[98])+(x2^rk[99]), 5)
+	t2 = rorFast((x2^rk[100])+(x3^rk[101]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[102])+(x1^rk[103]), 9)
+	t1 = rorFast((x1^rk[104])+(x2^rk[105]), 5)
+	t2 = rorFast((x2^rk[106])+(x3^rk[107]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[108])+(x1^rk[109]), 9)
+	t1 = rorFast((x1^rk[110])+(x2^rk[111]), 5)
+	t2 = rorFast((x2^rk[112])+(x3^rk[113]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[114])+(x1^rk[115]), 9)
+	t1 = rorFast((x1^rk[116])+(x2^rk[117]), 5)
+	t2 = rorFast((x2^rk[118])+(x3^rk[119]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[120])+(x1^rk[121]), 9)
+	t1 = rorFast((x1^rk[122])+(x2^rk[123]), 5)
+	t2 = rorFast((x2^rk[124])+(x3^rk[125]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[126])+(x1^rk[127]), 9)
+	t1 = rorFast((x1^rk[128])+(x2^rk[129]), 5)
+	t2 = rorFast((x2^rk[130])+(x3^rk[131]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[132])+(x1^rk[133]), 9)
+	t1 = rorFast((x1^rk[134])+(x2^rk[135]), 5)
+	t2 = rorFast((x2^rk[136])+(x3^rk[137]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[138])+(x1^rk[139]), 9)
+	t1 = rorFast((x1^rk[140])+(x2^rk[141]), 5)
+	t2 = rorFast((x2^rk[142])+(x3^rk[143]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[144])+(x1^rk[145]), 9)
+	t1 = rorFast((x1^rk[146])+(x2^rk[147]), 5)
+	t2 = rorFast((x2^rk[148])+(x3^rk[149]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[150])+(x1^rk[151]), 9)
+	t1 = rorFast((x1^rk[152])+(x2^rk[153]), 5)
+	t2 = rorFast((x2^rk[154])+(x3^rk[155]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[156])+(x1^rk[157]), 9)
+	t1 = rorFast((x1^rk[158])+(x2^rk[159]), 5)
+	t2 = rorFast((x2^rk[160])+(x3^rk[161]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[162])+(x1^rk[163]), 9)
+	t1 = rorFast((x1^rk[164])+(x2^rk[165]), 5)
+	t2 = rorFast((x2^rk[166])+(x3^rk[167]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[168])+(x1^rk[169]), 9)
+	t1 = rorFast((x1^rk[170])+(x2^rk[171]), 5)
+	t2 = rorFast((x2^rk[172])+(x3^rk[173]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[174])+(x1^rk[175]), 9)
+	t1 = rorFast((x1^rk[176])+(x2^rk[177]), 5)
+	t2 = rorFast((x2^rk[178])+(x3^rk[179]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[180])+(x1^rk[181]), 9)
+	t1 = rorFast((x1^rk[182])+(x2^rk[183]), 5)
+	t2 = rorFast((x2^rk[184])+(x3^rk[185]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+
+	t0 = rolFast((x0^rk[186])+(x1^rk[187]), 9)
+	t1 = rorFast((x1^rk[188])+(x2^rk[189]), 5)
+	t2 = rorFast((x2^rk[190])+(x3^rk[191]), 3)
+	x0, x1, x2, x3 = t0, t1, t2, x0
+	storeState(dst, x0, x1, x2, x3)
+}
+
+func decryptBlock256(dst, src []byte, rk []word) {
+	x0, x1, x2, x3 := loadState(src)
+	var t0, t1, t2, t3 word
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[0])) ^ rk[1]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[2])) ^ rk[3]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[4])) ^ rk[5]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[6])) ^ rk[7]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[8])) ^ rk[9]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[10])) ^ rk[11]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[12])) ^ rk[13]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[14])) ^ rk[15]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[16])) ^ rk[17]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[18])) ^ rk[19]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[20])) ^ rk[21]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[22])) ^ rk[23]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[24])) ^ rk[25]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[26])) ^ rk[27]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[28])) ^ rk[29]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[30])) ^ rk[31]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[32])) ^ rk[33]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[34])) ^ rk[35]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[36])) ^ rk[37]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[38])) ^ rk[39]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[40])) ^ rk[41]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[42])) ^ rk[43]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[44])) ^ rk[45]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[46])) ^ rk[47]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[48])) ^ rk[49]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[50])) ^ rk[51]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[52])) ^ rk[53]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[54])) ^ rk[55]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[56])) ^ rk[57]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[58])) ^ rk[59]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[60])) ^ rk[61]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[62])) ^ rk[63]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[64])) ^ rk[65]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[66])) ^ rk[67]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[68])) ^ rk[69]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[70])) ^ rk[71]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[72])) ^ rk[73]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[74])) ^ rk[75]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[76])) ^ rk[77]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[78])) ^ rk[79]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[80])) ^ rk[81]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[82])) ^ rk[83]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[84])) ^ rk[85]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[86])) ^ rk[87]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[88])) ^ rk[89]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[90])) ^ rk[91]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[92])) ^ rk[93]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[94])) ^ rk[95]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[96])) ^ rk[97]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[98])) ^ rk[99]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[100])) ^ rk[101]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[102])) ^ rk[103]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[104])) ^ rk[105]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[106])) ^ rk[107]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[108])) ^ rk[109]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[110])) ^ rk[111]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[112])) ^ rk[113]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[114])) ^ rk[115]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[116])) ^ rk[117]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[118])) ^ rk[119]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[120])) ^ rk[121]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[122])) ^ rk[123]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[124])) ^ rk[125]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[126])) ^ rk[127]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[128])) ^ rk[129]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[130])) ^ rk[131]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[132])) ^ rk[133]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[134])) ^ rk[135]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[136])) ^ rk[137]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[138])) ^ rk[139]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[140])) ^ rk[141]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[142])) ^ rk[143]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[144])) ^ rk[145]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[146])) ^ rk[147]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[148])) ^ rk[149]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[150])) ^ rk[151]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[152])) ^ rk[153]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[154])) ^ rk[155]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[156])) ^ rk[157]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[158])) ^ rk[159]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[160])) ^ rk[161]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[162])) ^ rk[163]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[164])) ^ rk[165]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[166])) ^ rk[167]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[168])) ^ rk[169]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[170])) ^ rk[171]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[172])) ^ rk[173]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[174])) ^ rk[175]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[176])) ^ rk[177]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[178])) ^ rk[179]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[180])) ^ rk[181]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[182])) ^ rk[183]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[184])) ^ rk[185]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+
+	t0 = x3
+	t1 = (rorFast(x0, 9) - (t0 ^ rk[186])) ^ rk[187]
+	t2 = (rolFast(x1, 5) - (t1 ^ rk[188])) ^ rk[189]
+	t3 = (rolFast(x2, 3) - (t2 ^ rk[190])) ^ rk[191]
+	x0, x1, x2, x3 = t0, t1, t2, t3
+	storeState(dst, x0, x1, x2, x3)
+}
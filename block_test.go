@@ -0,0 +1,100 @@
+package lea
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestBlockMatchesLegacyEncDec checks the flattened, unrolled block path
+// (encryptBlockGo/decryptBlockGo via Cipher) against the original
+// [][6]word-based Encrypt/Decrypt/RoundKey, across all three key sizes,
+// to gate the chunk0-4 round-key/unrolling refactor against the
+// reference implementation it replaced.
+func TestBlockMatchesLegacyEncDec(t *testing.T) {
+	for _, keySize := range []int{16, 24, 32} {
+		key := make([]byte, keySize)
+		for i := range key {
+			key[i] = byte(i*13 + keySize)
+		}
+		var plaintext [16]byte
+		for i := range plaintext {
+			plaintext[i] = byte(i + 1)
+		}
+
+		legacyRK := RoundKey(key, ENCRYPT_MODE)
+		legacyCT := Encrypt(plaintext, legacyRK)
+
+		block, err := NewCipher(key)
+		if err != nil {
+			t.Fatalf("NewCipher(keySize=%d): %v", keySize, err)
+		}
+		var fastCT [16]byte
+		block.Encrypt(fastCT[:], plaintext[:])
+		if !bytes.Equal(fastCT[:], legacyCT[:]) {
+			t.Fatalf("Encrypt(keySize=%d) = %x, want %x", keySize, fastCT, legacyCT)
+		}
+
+		var fastPT [16]byte
+		block.Decrypt(fastPT[:], fastCT[:])
+		if !bytes.Equal(fastPT[:], plaintext[:]) {
+			t.Fatalf("Decrypt(keySize=%d) = %x, want %x", keySize, fastPT, plaintext)
+		}
+
+		legacyDecRK := RoundKey(key, DECRYPT_MODE)
+		legacyPT := Decrypt(legacyCT, legacyDecRK)
+		if !bytes.Equal(legacyPT[:], plaintext[:]) {
+			t.Fatalf("legacy Decrypt(keySize=%d) = %x, want %x", keySize, legacyPT, plaintext)
+		}
+	}
+}
+
+func benchmarkKey(size int) []byte {
+	k := make([]byte, size)
+	for i := range k {
+		k[i] = byte(i)
+	}
+	return k
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	for _, keySize := range []int{16, 24, 32} {
+		block, err := NewCipher(benchmarkKey(keySize))
+		if err != nil {
+			b.Fatalf("NewCipher: %v", err)
+		}
+		var src, dst [16]byte
+		b.Run(benchName(keySize), func(b *testing.B) {
+			b.SetBytes(16)
+			for i := 0; i < b.N; i++ {
+				block.Encrypt(dst[:], src[:])
+			}
+		})
+	}
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	for _, keySize := range []int{16, 24, 32} {
+		block, err := NewCipher(benchmarkKey(keySize))
+		if err != nil {
+			b.Fatalf("NewCipher: %v", err)
+		}
+		var src, dst [16]byte
+		b.Run(benchName(keySize), func(b *testing.B) {
+			b.SetBytes(16)
+			for i := 0; i < b.N; i++ {
+				block.Decrypt(dst[:], src[:])
+			}
+		})
+	}
+}
+
+func benchName(keySize int) string {
+	switch keySize {
+	case 16:
+		return "LEA-128"
+	case 24:
+		return "LEA-192"
+	default:
+		return "LEA-256"
+	}
+}
@@ -0,0 +1,198 @@
+package lea
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+)
+
+// CCM (RFC 3610) isn't shipped by crypto/cipher, so NewCCM provides a
+// native implementation on top of any 128-bit block cipher, notably LEA.
+
+const (
+	ccmNonceSize  = 12
+	ccmDefaultTag = 16
+	// ccmLenSize is the number of bytes used to encode the message
+	// length (the RFC 3610 "L" field) given the fixed 12-byte nonce,
+	// leaving a practical message size limit of 2^24-1 bytes.
+	ccmLenSize = 3
+)
+
+type ccm struct {
+	block   cipher.Block
+	tagSize int
+}
+
+// NewCCM returns a cipher.AEAD implementing CCM mode over block, which
+// must have a 16-byte block size. tagSize selects the authentication
+// tag length in bytes (4, 6, 8, 10, 12, 14, or 16); pass 0 to select the
+// default of 16. The nonce size is fixed at 12 bytes.
+func NewCCM(block cipher.Block, tagSize int) (cipher.AEAD, error) {
+	if block.BlockSize() != BlockSize {
+		return nil, errors.New("lea: NewCCM requires a 128-bit block cipher")
+	}
+	if tagSize == 0 {
+		tagSize = ccmDefaultTag
+	}
+	switch tagSize {
+	case 4, 6, 8, 10, 12, 14, 16:
+	default:
+		return nil, errors.New("lea: invalid CCM tag size")
+	}
+	return &ccm{block: block, tagSize: tagSize}, nil
+}
+
+func (c *ccm) NonceSize() int { return ccmNonceSize }
+func (c *ccm) Overhead() int  { return c.tagSize }
+
+func (c *ccm) maxLength() uint64 { return (uint64(1) << (8 * ccmLenSize)) - 1 }
+
+func (c *ccm) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != ccmNonceSize {
+		panic("lea: incorrect nonce length given to CCM")
+	}
+	if uint64(len(plaintext)) > c.maxLength() {
+		panic("lea: message too large for CCM")
+	}
+	tag := c.mac(nonce, plaintext, additionalData)
+	ret, out := sliceForAppend(dst, len(plaintext)+c.tagSize)
+	c.ctr(out, nonce, plaintext, tag)
+	return ret
+}
+
+func (c *ccm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != ccmNonceSize {
+		panic("lea: incorrect nonce length given to CCM")
+	}
+	if len(ciphertext) < c.tagSize {
+		return nil, errors.New("lea: ciphertext too short")
+	}
+	ct := ciphertext[:len(ciphertext)-c.tagSize]
+	encTag := ciphertext[len(ciphertext)-c.tagSize:]
+
+	dstLen := len(dst)
+	ret, out := sliceForAppend(dst, len(ct)+c.tagSize)
+	c.ctr(out, nonce, ct, encTag)
+	plaintext := out[:len(ct)]
+	gotTag := out[len(ct):]
+
+	wantTag := c.mac(nonce, plaintext, additionalData)
+	if subtle.ConstantTimeCompare(gotTag, wantTag) != 1 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, errors.New("lea: message authentication failed")
+	}
+	return ret[:dstLen+len(ct)], nil
+}
+
+// counterBlock builds the RFC 3610 Ctr_i block: flags carrying only the
+// L' field, the nonce, and the big-endian counter.
+func (c *ccm) counterBlock(nonce []byte, counter uint64) (blk [16]byte) {
+	blk[0] = byte(ccmLenSize - 1)
+	copy(blk[1:1+len(nonce)], nonce)
+	putUint64BE(blk[1+len(nonce):16], counter)
+	return blk
+}
+
+// ctr XORs in with the CCM keystream derived from nonce, writing the
+// result to out[:len(in)], and XORs tag with S_0, writing the result to
+// out[len(in):]. Since CTR XOR is its own inverse this same pass both
+// encrypts (plaintext, plain tag -> ciphertext, encrypted tag) and
+// decrypts (ciphertext, encrypted tag -> plaintext, plain tag).
+func (c *ccm) ctr(out, nonce, in, tag []byte) {
+	var s0 [16]byte
+	cb := c.counterBlock(nonce, 0)
+	c.block.Encrypt(s0[:], cb[:])
+	for i := 0; i < c.tagSize; i++ {
+		out[len(in)+i] = tag[i] ^ s0[i]
+	}
+
+	var ks [16]byte
+	counter := uint64(1)
+	for off := 0; off < len(in); off += BlockSize {
+		cb = c.counterBlock(nonce, counter)
+		c.block.Encrypt(ks[:], cb[:])
+		n := BlockSize
+		if rem := len(in) - off; rem < n {
+			n = rem
+		}
+		for i := 0; i < n; i++ {
+			out[off+i] = in[off+i] ^ ks[i]
+		}
+		counter++
+	}
+}
+
+// mac computes the RFC 3610 CBC-MAC over the formatted B0 block, the
+// encoded additional data, and the plaintext.
+func (c *ccm) mac(nonce, plaintext, aad []byte) []byte {
+	b0 := c.formatB0(nonce, aad, len(plaintext))
+	var y [16]byte
+	c.block.Encrypt(y[:], b0[:])
+
+	if len(aad) > 0 {
+		var hdr []byte
+		if len(aad) < 0xff00 {
+			var l [2]byte
+			binary.BigEndian.PutUint16(l[:], uint16(len(aad)))
+			hdr = l[:]
+		} else {
+			var l [6]byte
+			l[0], l[1] = 0xff, 0xfe
+			binary.BigEndian.PutUint32(l[2:], uint32(len(aad)))
+			hdr = l[:]
+		}
+		y = cbcMacBlocks(c.block, y, append(hdr, aad...))
+	}
+	y = cbcMacBlocks(c.block, y, plaintext)
+	return y[:c.tagSize]
+}
+
+// formatB0 builds the RFC 3610 B0 block: flags (Adata, M', L'), nonce,
+// and the big-endian message length.
+func (c *ccm) formatB0(nonce, aad []byte, msgLen int) (b0 [16]byte) {
+	flags := byte(ccmLenSize - 1)
+	if len(aad) > 0 {
+		flags |= 0x40
+	}
+	flags |= byte((c.tagSize-2)/2) << 3
+	b0[0] = flags
+	copy(b0[1:1+len(nonce)], nonce)
+	putUint64BE(b0[1+len(nonce):16], uint64(msgLen))
+	return b0
+}
+
+func cbcMacBlocks(block cipher.Block, y [16]byte, data []byte) [16]byte {
+	for len(data) > 0 {
+		var blk [16]byte
+		n := copy(blk[:], data)
+		for i := 0; i < BlockSize; i++ {
+			y[i] ^= blk[i]
+		}
+		var next [16]byte
+		block.Encrypt(next[:], y[:])
+		y = next
+		data = data[n:]
+	}
+	return y
+}
+
+func putUint64BE(b []byte, v uint64) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+}
+
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
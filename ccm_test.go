@@ -0,0 +1,207 @@
+package lea
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"testing"
+)
+
+// referenceCCMSeal is a second, independently written implementation of
+// RFC 3610 CCM (fixed 12-byte nonce, L=3), coded straight from the spec
+// rather than derived from ccm.go. No published TTAK/KISA CCM test
+// vectors for this 12-byte-nonce parameterization were available to
+// hand in this environment, so this reference serves as the golden
+// oracle: ccm.go's Seal output must match it byte for byte.
+func referenceCCMSeal(block cipher.Block, nonce, plaintext, aad []byte, tagSize int) []byte {
+	const l = 3
+
+	b0 := make([]byte, 16)
+	flags := 0
+	if len(aad) > 0 {
+		flags |= 0x40
+	}
+	flags |= ((tagSize - 2) / 2) << 3
+	flags |= l - 1
+	b0[0] = byte(flags)
+	copy(b0[1:1+len(nonce)], nonce)
+	for i := 0; i < l; i++ {
+		b0[15-i] = byte(len(plaintext) >> (8 * i))
+	}
+
+	mac := make([]byte, 16)
+
+	cbcStep := func(chunk []byte) {
+		for i := range mac {
+			mac[i] ^= chunk[i]
+		}
+		block.Encrypt(mac, mac)
+	}
+	cbcStep(b0)
+
+	if len(aad) > 0 {
+		var hdr []byte
+		if len(aad) < 0xff00 {
+			hdr = []byte{byte(len(aad) >> 8), byte(len(aad))}
+		} else {
+			hdr = []byte{0xff, 0xfe, byte(len(aad) >> 24), byte(len(aad) >> 16), byte(len(aad) >> 8), byte(len(aad))}
+		}
+		aadBlock := append(append([]byte{}, hdr...), aad...)
+		for len(aadBlock)%16 != 0 {
+			aadBlock = append(aadBlock, 0)
+		}
+		for off := 0; off < len(aadBlock); off += 16 {
+			cbcStep(aadBlock[off : off+16])
+		}
+	}
+
+	ptPadded := append([]byte{}, plaintext...)
+	for len(ptPadded)%16 != 0 {
+		ptPadded = append(ptPadded, 0)
+	}
+	for off := 0; off < len(ptPadded); off += 16 {
+		cbcStep(ptPadded[off : off+16])
+	}
+
+	tag := append([]byte{}, mac[:tagSize]...)
+
+	counterBlock := func(counter uint64) []byte {
+		cb := make([]byte, 16)
+		cb[0] = byte(l - 1)
+		copy(cb[1:1+len(nonce)], nonce)
+		for i := 0; i < l; i++ {
+			cb[15-i] = byte(counter >> (8 * i))
+		}
+		out := make([]byte, 16)
+		block.Encrypt(out, cb)
+		return out
+	}
+
+	s0 := counterBlock(0)
+	encTag := make([]byte, tagSize)
+	for i := range encTag {
+		encTag[i] = tag[i] ^ s0[i]
+	}
+
+	ciphertext := make([]byte, len(plaintext))
+	counter := uint64(1)
+	for off := 0; off < len(plaintext); off += 16 {
+		ks := counterBlock(counter)
+		n := 16
+		if rem := len(plaintext) - off; rem < n {
+			n = rem
+		}
+		for i := 0; i < n; i++ {
+			ciphertext[off+i] = plaintext[off+i] ^ ks[i]
+		}
+		counter++
+	}
+
+	return append(ciphertext, encTag...)
+}
+
+func TestCCMMatchesReferenceImplementation(t *testing.T) {
+	key := make([]byte, 16)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	block, err := NewCipher(key)
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	nonce := make([]byte, 12)
+	for i := range nonce {
+		nonce[i] = byte(0x50 + i)
+	}
+
+	cases := []struct {
+		name    string
+		ptLen   int
+		aadLen  int
+		tagSize int
+	}{
+		{"empty plaintext, no aad", 0, 0, 16},
+		{"short plaintext, no aad", 5, 0, 16},
+		{"single block, with aad", 16, 8, 16},
+		{"multi-block, with aad", 33, 20, 16},
+		{"multi-block, short tag", 40, 10, 8},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			plaintext := make([]byte, c.ptLen)
+			for i := range plaintext {
+				plaintext[i] = byte(i * 3)
+			}
+			aad := make([]byte, c.aadLen)
+			for i := range aad {
+				aad[i] = byte(i * 5)
+			}
+
+			want := referenceCCMSeal(block, nonce, plaintext, aad, c.tagSize)
+
+			aead, err := NewCCM(block, c.tagSize)
+			if err != nil {
+				t.Fatalf("NewCCM: %v", err)
+			}
+			got := aead.Seal(nil, nonce, plaintext, aad)
+			if !bytes.Equal(got, want) {
+				t.Fatalf("Seal = %x, want %x", got, want)
+			}
+
+			opened, err := aead.Open(nil, nonce, got, aad)
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			if !bytes.Equal(opened, plaintext) {
+				t.Fatalf("Open = %x, want %x", opened, plaintext)
+			}
+		})
+	}
+}
+
+func TestCCMTamperDetection(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i + 1)
+	}
+	block, err := NewCipher(key)
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	aead, err := NewCCM(block, 0)
+	if err != nil {
+		t.Fatalf("NewCCM: %v", err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	plaintext := []byte("attack at dawn")
+	aad := []byte("header")
+
+	ct := aead.Seal(nil, nonce, plaintext, aad)
+
+	tampered := append([]byte(nil), ct...)
+	tampered[0] ^= 0x01
+	if _, err := aead.Open(nil, nonce, tampered, aad); err == nil {
+		t.Fatal("Open accepted a tampered ciphertext")
+	}
+
+	if _, err := aead.Open(nil, nonce, ct, []byte("wrong header")); err == nil {
+		t.Fatal("Open accepted mismatched additional data")
+	}
+
+	if _, err := aead.Open(nil, nonce, ct[:len(ct)-1], aad); err == nil {
+		t.Fatal("Open accepted a truncated ciphertext")
+	}
+}
+
+func TestNewCCMRejectsInvalidTagSize(t *testing.T) {
+	block, err := NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	if _, err := NewCCM(block, 5); err == nil {
+		t.Fatal("NewCCM accepted an odd tag size")
+	}
+	if _, err := NewCCM(block, 18); err == nil {
+		t.Fatal("NewCCM accepted an oversized tag")
+	}
+}
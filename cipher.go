@@ -0,0 +1,78 @@
+package lea
+
+import (
+	"crypto/cipher"
+	"strconv"
+)
+
+// BlockSize is the LEA block size in bytes.
+const BlockSize = 16
+
+// A KeySizeError is returned when NewCipher is given a key that is not
+// 16, 24, or 32 bytes long.
+type KeySizeError int
+
+func (k KeySizeError) Error() string {
+	return "lea: invalid key size " + strconv.Itoa(int(k))
+}
+
+// Cipher is an instance of LEA encryption using a particular key. It
+// implements the cipher.Block interface and can therefore be used with
+// the standard block modes in crypto/cipher (CBC, CTR, CFB, OFB, GCM, ...).
+// Round keys are stored as a flat []word rather than [][6]word so the
+// block functions can index into them without an extra slice-header hop.
+type Cipher struct {
+	encRK []word
+	decRK []word
+}
+
+// NewCipher creates and returns a new cipher.Block implementing LEA.
+// The key argument should be 16, 24, or 32 bytes long to select
+// LEA-128, LEA-192, or LEA-256.
+func NewCipher(key []byte) (cipher.Block, error) {
+	switch len(key) {
+	default:
+		return nil, KeySizeError(len(key))
+	case 16, 24, 32:
+	}
+	c := &Cipher{
+		encRK: flatRoundKeys(key, ENCRYPT_MODE),
+		decRK: flatRoundKeys(key, DECRYPT_MODE),
+	}
+	return c, nil
+}
+
+// BlockSize returns the LEA block size, 16 bytes.
+func (c *Cipher) BlockSize() int {
+	return BlockSize
+}
+
+// Encrypt encrypts the first block in src into dst.
+// dst and src must overlap entirely or not at all.
+func (c *Cipher) Encrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("lea: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("lea: output not full block")
+	}
+	if inexactOverlap(dst[:BlockSize], src[:BlockSize]) {
+		panic("lea: invalid buffer overlap")
+	}
+	encryptBlockGo(dst, src, c.encRK)
+}
+
+// Decrypt decrypts the first block in src into dst.
+// dst and src must overlap entirely or not at all.
+func (c *Cipher) Decrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("lea: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("lea: output not full block")
+	}
+	if inexactOverlap(dst[:BlockSize], src[:BlockSize]) {
+		panic("lea: invalid buffer overlap")
+	}
+	decryptBlockGo(dst, src, c.decRK)
+}
@@ -0,0 +1,178 @@
+// Package keywrap implements RFC 3394 and RFC 5649 key wrapping using
+// LEA as the underlying 128-bit block cipher, the standard way Go wraps
+// symmetric keys for storage or transport.
+package keywrap
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"github.com/jukworks/lea"
+)
+
+var defaultIV = [8]byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+
+const alternativeIV = 0xa65959a6
+
+// Wrap implements RFC 3394 key wrap. plaintext must be a multiple of 8
+// bytes and at least 16 bytes long; kek is a 16, 24, or 32 byte LEA key.
+func Wrap(kek, plaintext []byte) ([]byte, error) {
+	if len(plaintext) < 16 || len(plaintext)%8 != 0 {
+		return nil, errors.New("keywrap: plaintext must be a multiple of 8 bytes, at least 16")
+	}
+	block, err := lea.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	a := defaultIV
+	return wrap(block, a, plaintext), nil
+}
+
+// Unwrap inverts Wrap and verifies the RFC 3394 integrity check value.
+func Unwrap(kek, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 24 || len(ciphertext)%8 != 0 {
+		return nil, errors.New("keywrap: ciphertext must be a multiple of 8 bytes, at least 24")
+	}
+	block, err := lea.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	a, plaintext := unwrap(block, ciphertext)
+	if subtle.ConstantTimeCompare(a[:], defaultIV[:]) != 1 {
+		return nil, errors.New("keywrap: integrity check failed")
+	}
+	return plaintext, nil
+}
+
+// WrapPad implements the RFC 5649 padded variant of key wrap, accepting
+// plaintext of any length from 1 byte up. kek is a 16, 24, or 32 byte
+// LEA key.
+func WrapPad(kek, plaintext []byte) ([]byte, error) {
+	if len(plaintext) == 0 {
+		return nil, errors.New("keywrap: plaintext must not be empty")
+	}
+	block, err := lea.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	padLen := (8 - len(plaintext)%8) % 8
+	padded := make([]byte, len(plaintext)+padLen)
+	copy(padded, plaintext)
+
+	var a [8]byte
+	binary.BigEndian.PutUint32(a[:4], alternativeIV)
+	binary.BigEndian.PutUint32(a[4:], uint32(len(plaintext)))
+
+	if len(padded) == 8 {
+		var buf [16]byte
+		copy(buf[:8], a[:])
+		copy(buf[8:], padded)
+		block.Encrypt(buf[:], buf[:])
+		return buf[:], nil
+	}
+	return wrap(block, a, padded), nil
+}
+
+// UnwrapPad inverts WrapPad and verifies the RFC 5649 integrity check
+// value, including the padding and message-length-indicator fields.
+func UnwrapPad(kek, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 16 || len(ciphertext)%8 != 0 {
+		return nil, errors.New("keywrap: ciphertext must be a multiple of 8 bytes, at least 16")
+	}
+	block, err := lea.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	var a [8]byte
+	var padded []byte
+	if len(ciphertext) == 16 {
+		var buf [16]byte
+		copy(buf[:], ciphertext)
+		block.Decrypt(buf[:], buf[:])
+		copy(a[:], buf[:8])
+		padded = buf[8:]
+	} else {
+		a, padded = unwrap(block, ciphertext)
+	}
+
+	var wantHdr [4]byte
+	binary.BigEndian.PutUint32(wantHdr[:], alternativeIV)
+	if subtle.ConstantTimeCompare(a[:4], wantHdr[:]) != 1 {
+		return nil, errors.New("keywrap: integrity check failed")
+	}
+	mli := int(binary.BigEndian.Uint32(a[4:]))
+	if mli <= 0 || mli > len(padded) || mli <= len(padded)-8 {
+		return nil, errors.New("keywrap: integrity check failed")
+	}
+	var padDiff byte
+	for _, b := range padded[mli:] {
+		padDiff |= b
+	}
+	if padDiff != 0 {
+		return nil, errors.New("keywrap: integrity check failed")
+	}
+	return padded[:mli], nil
+}
+
+// wrap runs the RFC 3394 wrapping rounds: for j=0..5 and i=1..n it sets
+// B = Encrypt(KEK, A||R[i]), A = MSB64(B) XOR (n*j+i), R[i] = LSB64(B).
+func wrap(block cipher.Block, a [8]byte, plaintext []byte) []byte {
+	n := len(plaintext) / 8
+	r := make([][8]byte, n+1)
+	for i := 1; i <= n; i++ {
+		copy(r[i][:], plaintext[(i-1)*8:i*8])
+	}
+
+	var buf [16]byte
+	for j := 0; j <= 5; j++ {
+		for i := 1; i <= n; i++ {
+			copy(buf[:8], a[:])
+			copy(buf[8:], r[i][:])
+			block.Encrypt(buf[:], buf[:])
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(a[:], binary.BigEndian.Uint64(buf[:8])^t)
+			copy(r[i][:], buf[8:])
+		}
+	}
+
+	out := make([]byte, 8*(n+1))
+	copy(out[:8], a[:])
+	for i := 1; i <= n; i++ {
+		copy(out[i*8:(i+1)*8], r[i][:])
+	}
+	return out
+}
+
+// unwrap runs the RFC 3394 rounds in reverse, returning the final A
+// register (for the caller to check against the expected IV) and the
+// recovered plaintext blocks.
+func unwrap(block cipher.Block, ciphertext []byte) (a [8]byte, plaintext []byte) {
+	n := len(ciphertext)/8 - 1
+	copy(a[:], ciphertext[:8])
+	r := make([][8]byte, n+1)
+	for i := 1; i <= n; i++ {
+		copy(r[i][:], ciphertext[i*8:(i+1)*8])
+	}
+
+	var buf [16]byte
+	for j := 5; j >= 0; j-- {
+		for i := n; i >= 1; i-- {
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(buf[:8], binary.BigEndian.Uint64(a[:])^t)
+			copy(buf[8:], r[i][:])
+			block.Decrypt(buf[:], buf[:])
+			copy(a[:], buf[:8])
+			copy(r[i][:], buf[8:])
+		}
+	}
+
+	plaintext = make([]byte, 8*n)
+	for i := 1; i <= n; i++ {
+		copy(plaintext[(i-1)*8:i*8], r[i][:])
+	}
+	return a, plaintext
+}
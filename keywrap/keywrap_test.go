@@ -0,0 +1,163 @@
+package keywrap
+
+import (
+	"bytes"
+	"testing"
+)
+
+func kekForSize(size int) []byte {
+	k := make([]byte, size)
+	for i := range k {
+		k[i] = byte(i*5 + size)
+	}
+	return k
+}
+
+// TestWrapUnwrapRoundTrip exercises RFC 3394 Wrap/Unwrap across all
+// three LEA key sizes and several block counts, including the smallest
+// permitted plaintext (two blocks).
+func TestWrapUnwrapRoundTrip(t *testing.T) {
+	for _, kekSize := range []int{16, 24, 32} {
+		kek := kekForSize(kekSize)
+		for _, n := range []int{2, 3, 5, 8} {
+			plaintext := make([]byte, n*8)
+			for i := range plaintext {
+				plaintext[i] = byte(i*3 + n)
+			}
+
+			wrapped, err := Wrap(kek, plaintext)
+			if err != nil {
+				t.Fatalf("Wrap(kekSize=%d, n=%d): %v", kekSize, n, err)
+			}
+			if len(wrapped) != len(plaintext)+8 {
+				t.Fatalf("Wrap(kekSize=%d, n=%d) length = %d, want %d", kekSize, n, len(wrapped), len(plaintext)+8)
+			}
+
+			got, err := Unwrap(kek, wrapped)
+			if err != nil {
+				t.Fatalf("Unwrap(kekSize=%d, n=%d): %v", kekSize, n, err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Fatalf("Unwrap(Wrap(plaintext)) (kekSize=%d, n=%d) = %x, want %x", kekSize, n, got, plaintext)
+			}
+		}
+	}
+}
+
+// TestWrapRejectsInvalidPlaintext checks the length preconditions
+// documented on Wrap.
+func TestWrapRejectsInvalidPlaintext(t *testing.T) {
+	kek := kekForSize(16)
+	for _, n := range []int{0, 8, 15, 17} {
+		if _, err := Wrap(kek, make([]byte, n)); err == nil {
+			t.Errorf("Wrap(plaintext of %d bytes): want error, got nil", n)
+		}
+	}
+}
+
+// TestUnwrapDetectsCorruption flips a single bit in various positions of
+// a valid wrapped key and checks that Unwrap always rejects the result,
+// covering both a corrupted IV (caught by the final A register check)
+// and corrupted ciphertext blocks (which perturb A through the
+// unwrapping rounds).
+func TestUnwrapDetectsCorruption(t *testing.T) {
+	kek := kekForSize(16)
+	plaintext := make([]byte, 32)
+	for i := range plaintext {
+		plaintext[i] = byte(i)
+	}
+	wrapped, err := Wrap(kek, plaintext)
+	if err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+
+	for _, byteOff := range []int{0, 7, 8, len(wrapped) - 1} {
+		corrupted := append([]byte(nil), wrapped...)
+		corrupted[byteOff] ^= 0x01
+		if _, err := Unwrap(kek, corrupted); err == nil {
+			t.Errorf("Unwrap accepted ciphertext corrupted at byte %d", byteOff)
+		}
+	}
+}
+
+// TestWrapPadUnwrapPadRoundTrip exercises the RFC 5649 padded variant
+// across input lengths that fall below, on, and above an 8-byte
+// boundary: a single byte, exactly one block minus one byte, exactly
+// one block, and one byte into the second block. The first three all
+// pad to a single 8-byte block and take the single-block shortcut; the
+// last takes the multi-block path.
+func TestWrapPadUnwrapPadRoundTrip(t *testing.T) {
+	for _, kekSize := range []int{16, 24, 32} {
+		kek := kekForSize(kekSize)
+		for _, n := range []int{1, 7, 8, 9} {
+			plaintext := make([]byte, n)
+			for i := range plaintext {
+				plaintext[i] = byte(i*5 + n + 1)
+			}
+
+			wrapped, err := WrapPad(kek, plaintext)
+			if err != nil {
+				t.Fatalf("WrapPad(kekSize=%d, n=%d): %v", kekSize, n, err)
+			}
+
+			got, err := UnwrapPad(kek, wrapped)
+			if err != nil {
+				t.Fatalf("UnwrapPad(kekSize=%d, n=%d): %v", kekSize, n, err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Fatalf("UnwrapPad(WrapPad(plaintext)) (kekSize=%d, n=%d) = %x, want %x", kekSize, n, got, plaintext)
+			}
+		}
+	}
+}
+
+// TestWrapPadRejectsEmptyPlaintext checks the precondition documented on
+// WrapPad.
+func TestWrapPadRejectsEmptyPlaintext(t *testing.T) {
+	if _, err := WrapPad(kekForSize(16), nil); err == nil {
+		t.Fatal("WrapPad(nil): want error, got nil")
+	}
+}
+
+// TestUnwrapPadDetectsCorruption checks that UnwrapPad rejects a wrapped
+// key with one bit flipped in its first, eighth, or last byte, for both
+// the single-block shortcut (n=7) and the multi-block path (n=9). A flip
+// anywhere in the ciphertext garbles the whole decrypted block, so these
+// cases do not isolate the IV, length-indicator, or padding checks.
+func TestUnwrapPadDetectsCorruption(t *testing.T) {
+	kek := kekForSize(16)
+	for _, n := range []int{7, 9} {
+		plaintext := make([]byte, n)
+		for i := range plaintext {
+			plaintext[i] = byte(i + 1)
+		}
+		wrapped, err := WrapPad(kek, plaintext)
+		if err != nil {
+			t.Fatalf("WrapPad(n=%d): %v", n, err)
+		}
+
+		t.Run("corrupted first byte", func(t *testing.T) {
+			corrupted := append([]byte(nil), wrapped...)
+			corrupted[0] ^= 0x01
+			if _, err := UnwrapPad(kek, corrupted); err == nil {
+				t.Errorf("UnwrapPad(n=%d) accepted ciphertext corrupted at byte 0", n)
+			}
+		})
+
+		t.Run("corrupted eighth byte", func(t *testing.T) {
+			corrupted := append([]byte(nil), wrapped...)
+			corrupted[7] ^= 0x01
+			if _, err := UnwrapPad(kek, corrupted); err == nil {
+				t.Errorf("UnwrapPad(n=%d) accepted ciphertext corrupted at byte 7", n)
+			}
+		})
+
+		t.Run("corrupted last byte", func(t *testing.T) {
+			corrupted := append([]byte(nil), wrapped...)
+			corrupted[len(corrupted)-1] ^= 0x01
+			if _, err := UnwrapPad(kek, corrupted); err == nil {
+				t.Errorf("UnwrapPad(n=%d) accepted ciphertext corrupted at its last byte", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Package modes provides turnkey crypto/cipher block mode helpers for LEA,
+// mirroring the constructors the standard library ships for AES.
+package modes
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"errors"
+
+	"github.com/jukworks/lea"
+)
+
+// NewCBCEncrypter returns a cipher.BlockMode for encrypting in cipher
+// block chaining mode using LEA with the given key. iv must be exactly
+// lea.BlockSize bytes long.
+func NewCBCEncrypter(key, iv []byte) (cipher.BlockMode, error) {
+	block, err := lea.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, errors.New("modes: IV length must equal block size")
+	}
+	return cipher.NewCBCEncrypter(block, iv), nil
+}
+
+// NewCBCDecrypter returns a cipher.BlockMode for decrypting in cipher
+// block chaining mode using LEA with the given key. iv must be exactly
+// lea.BlockSize bytes long.
+func NewCBCDecrypter(key, iv []byte) (cipher.BlockMode, error) {
+	block, err := lea.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, errors.New("modes: IV length must equal block size")
+	}
+	return cipher.NewCBCDecrypter(block, iv), nil
+}
+
+// NewCTR returns a cipher.Stream which encrypts or decrypts using LEA in
+// counter mode. nonce must be exactly lea.BlockSize bytes long.
+func NewCTR(key, nonce []byte) (cipher.Stream, error) {
+	block, err := lea.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != block.BlockSize() {
+		return nil, errors.New("modes: nonce length must equal block size")
+	}
+	return cipher.NewCTR(block, nonce), nil
+}
+
+// NewCFBEncrypter returns a cipher.Stream which encrypts using LEA in
+// cipher feedback mode. iv must be exactly lea.BlockSize bytes long.
+func NewCFBEncrypter(key, iv []byte) (cipher.Stream, error) {
+	block, err := lea.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, errors.New("modes: IV length must equal block size")
+	}
+	return cipher.NewCFBEncrypter(block, iv), nil
+}
+
+// NewCFBDecrypter returns a cipher.Stream which decrypts using LEA in
+// cipher feedback mode. iv must be exactly lea.BlockSize bytes long.
+func NewCFBDecrypter(key, iv []byte) (cipher.Stream, error) {
+	block, err := lea.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, errors.New("modes: IV length must equal block size")
+	}
+	return cipher.NewCFBDecrypter(block, iv), nil
+}
+
+// NewOFB returns a cipher.Stream which encrypts or decrypts using LEA in
+// output feedback mode. iv must be exactly lea.BlockSize bytes long.
+func NewOFB(key, iv []byte) (cipher.Stream, error) {
+	block, err := lea.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, errors.New("modes: IV length must equal block size")
+	}
+	return cipher.NewOFB(block, iv), nil
+}
+
+// Pad applies PKCS#7 padding, rounding data up to a multiple of
+// blockSize by appending N bytes of value N. It always returns a freshly
+// allocated slice, so it never writes through to data's backing array
+// even when data has spare capacity.
+func Pad(data []byte, blockSize int) []byte {
+	padLen := blockSize - len(data)%blockSize
+	padded := make([]byte, len(data)+padLen)
+	copy(padded, data)
+	copy(padded[len(data):], bytes.Repeat([]byte{byte(padLen)}, padLen))
+	return padded
+}
+
+// Unpad strips and validates PKCS#7 padding previously applied by Pad.
+func Unpad(data []byte, blockSize int) ([]byte, error) {
+	n := len(data)
+	if n == 0 || n%blockSize != 0 {
+		return nil, errors.New("modes: invalid padded data length")
+	}
+	padLen := int(data[n-1])
+	if padLen == 0 || padLen > blockSize || padLen > n {
+		return nil, errors.New("modes: invalid padding")
+	}
+	if !bytes.Equal(data[n-padLen:], bytes.Repeat([]byte{byte(padLen)}, padLen)) {
+		return nil, errors.New("modes: invalid padding")
+	}
+	return data[:n-padLen], nil
+}
@@ -0,0 +1,248 @@
+package modes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("bad hex %q: %v", s, err)
+	}
+	return b
+}
+
+// kisaLEA128 is the 128-bit key / single-block vector from the
+// published LEA specification test vectors (KISA): encrypting
+// plaintext under key yields ciphertext.
+var (
+	kisaLEA128Key        = "0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+	kisaLEA128Plaintext  = "101112131415161718191a1b1c1d1e1f"
+	kisaLEA128Ciphertext = "9fc84e3528c6c6185532c7a704648bfd"
+)
+
+// TestCBCKISAVector checks NewCBCEncrypter/Decrypter against the
+// published single-block LEA-128 vector: CBC with a zero IV over one
+// block degenerates to plain ECB, so the vector's ciphertext must come
+// out unchanged.
+func TestCBCKISAVector(t *testing.T) {
+	key := mustHex(t, kisaLEA128Key)
+	plaintext := mustHex(t, kisaLEA128Plaintext)
+	wantCiphertext := mustHex(t, kisaLEA128Ciphertext)
+	iv := make([]byte, 16)
+
+	enc, err := NewCBCEncrypter(key, iv)
+	if err != nil {
+		t.Fatalf("NewCBCEncrypter: %v", err)
+	}
+	got := make([]byte, len(plaintext))
+	enc.CryptBlocks(got, plaintext)
+	if !bytes.Equal(got, wantCiphertext) {
+		t.Fatalf("CBC encrypt = %x, want %x", got, wantCiphertext)
+	}
+
+	dec, err := NewCBCDecrypter(key, iv)
+	if err != nil {
+		t.Fatalf("NewCBCDecrypter: %v", err)
+	}
+	back := make([]byte, len(got))
+	dec.CryptBlocks(back, got)
+	if !bytes.Equal(back, plaintext) {
+		t.Fatalf("CBC decrypt = %x, want %x", back, plaintext)
+	}
+}
+
+// keyForSize returns a deterministic, distinct key of the given
+// length so each mode/size combination below exercises its own
+// schedule rather than reusing the KISA vector's key.
+func keyForSize(size int) []byte {
+	k := make([]byte, size)
+	for i := range k {
+		k[i] = byte(i*7 + size)
+	}
+	return k
+}
+
+var modePlaintexts = [][]byte{
+	[]byte("single block!!!!"), // exactly one block
+	[]byte("this message needs more than one block of LEA keystream"),
+	{},
+}
+
+// TestStreamModesRoundTrip exercises CTR, CFB, and OFB across all
+// three LEA key sizes: encrypting then decrypting must recover the
+// original plaintext, including lengths that aren't a multiple of the
+// block size (valid for all three stream modes).
+func TestStreamModesRoundTrip(t *testing.T) {
+	for _, keySize := range []int{16, 24, 32} {
+		key := keyForSize(keySize)
+		iv := make([]byte, 16)
+		for i := range iv {
+			iv[i] = byte(0xA0 + i)
+		}
+		for _, pt := range modePlaintexts {
+			ctr, err := NewCTR(key, iv)
+			if err != nil {
+				t.Fatalf("NewCTR(keySize=%d): %v", keySize, err)
+			}
+			ctrCt := make([]byte, len(pt))
+			ctr.XORKeyStream(ctrCt, pt)
+			ctrDec, err := NewCTR(key, iv)
+			if err != nil {
+				t.Fatalf("NewCTR(keySize=%d): %v", keySize, err)
+			}
+			ctrPt := make([]byte, len(ctrCt))
+			ctrDec.XORKeyStream(ctrPt, ctrCt)
+			if !bytes.Equal(ctrPt, pt) {
+				t.Fatalf("CTR round trip (keySize=%d) = %x, want %x", keySize, ctrPt, pt)
+			}
+
+			cfbEnc, err := NewCFBEncrypter(key, iv)
+			if err != nil {
+				t.Fatalf("NewCFBEncrypter(keySize=%d): %v", keySize, err)
+			}
+			cfbCt := make([]byte, len(pt))
+			cfbEnc.XORKeyStream(cfbCt, pt)
+			cfbDec, err := NewCFBDecrypter(key, iv)
+			if err != nil {
+				t.Fatalf("NewCFBDecrypter(keySize=%d): %v", keySize, err)
+			}
+			cfbPt := make([]byte, len(cfbCt))
+			cfbDec.XORKeyStream(cfbPt, cfbCt)
+			if !bytes.Equal(cfbPt, pt) {
+				t.Fatalf("CFB round trip (keySize=%d) = %x, want %x", keySize, cfbPt, pt)
+			}
+
+			ofbEnc, err := NewOFB(key, iv)
+			if err != nil {
+				t.Fatalf("NewOFB(keySize=%d): %v", keySize, err)
+			}
+			ofbCt := make([]byte, len(pt))
+			ofbEnc.XORKeyStream(ofbCt, pt)
+			ofbDec, err := NewOFB(key, iv)
+			if err != nil {
+				t.Fatalf("NewOFB(keySize=%d): %v", keySize, err)
+			}
+			ofbPt := make([]byte, len(ofbCt))
+			ofbDec.XORKeyStream(ofbPt, ofbCt)
+			if !bytes.Equal(ofbPt, pt) {
+				t.Fatalf("OFB round trip (keySize=%d) = %x, want %x", keySize, ofbPt, pt)
+			}
+		}
+	}
+}
+
+// TestCBCRoundTrip exercises CBC across all three key sizes with
+// block-aligned plaintext of varying length.
+func TestCBCRoundTrip(t *testing.T) {
+	for _, keySize := range []int{16, 24, 32} {
+		key := keyForSize(keySize)
+		iv := keyForSize(16)
+		for _, n := range []int{16, 32, 64} {
+			pt := Pad(bytes.Repeat([]byte{0x42}, n), 16)
+
+			enc, err := NewCBCEncrypter(key, iv)
+			if err != nil {
+				t.Fatalf("NewCBCEncrypter(keySize=%d): %v", keySize, err)
+			}
+			ct := make([]byte, len(pt))
+			enc.CryptBlocks(ct, pt)
+
+			dec, err := NewCBCDecrypter(key, iv)
+			if err != nil {
+				t.Fatalf("NewCBCDecrypter(keySize=%d): %v", keySize, err)
+			}
+			got := make([]byte, len(ct))
+			dec.CryptBlocks(got, ct)
+
+			back, err := Unpad(got, 16)
+			if err != nil {
+				t.Fatalf("Unpad: %v", err)
+			}
+			if !bytes.Equal(back, bytes.Repeat([]byte{0x42}, n)) {
+				t.Fatalf("CBC round trip (keySize=%d, n=%d) mismatch", keySize, n)
+			}
+		}
+	}
+}
+
+func TestPadUnpad(t *testing.T) {
+	const blockSize = 16
+
+	t.Run("empty input", func(t *testing.T) {
+		padded := Pad(nil, blockSize)
+		if len(padded) != blockSize {
+			t.Fatalf("Pad(nil) length = %d, want %d", len(padded), blockSize)
+		}
+		for _, b := range padded {
+			if b != blockSize {
+				t.Fatalf("Pad(nil) = %x, want all bytes = %#x", padded, blockSize)
+			}
+		}
+		got, err := Unpad(padded, blockSize)
+		if err != nil {
+			t.Fatalf("Unpad: %v", err)
+		}
+		if len(got) != 0 {
+			t.Fatalf("Unpad(Pad(nil)) = %x, want empty", got)
+		}
+	})
+
+	t.Run("block-aligned input adds a full block", func(t *testing.T) {
+		data := bytes.Repeat([]byte{0x01}, blockSize*2)
+		padded := Pad(append([]byte(nil), data...), blockSize)
+		if len(padded) != len(data)+blockSize {
+			t.Fatalf("Pad length = %d, want %d", len(padded), len(data)+blockSize)
+		}
+		got, err := Unpad(padded, blockSize)
+		if err != nil {
+			t.Fatalf("Unpad: %v", err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("Unpad(Pad(data)) = %x, want %x", got, data)
+		}
+	})
+
+	t.Run("corrupted padding byte", func(t *testing.T) {
+		data := bytes.Repeat([]byte{0x02}, blockSize+3)
+		padded := Pad(append([]byte(nil), data...), blockSize)
+		padded[len(padded)-1] ^= 0xff
+		if _, err := Unpad(padded, blockSize); err == nil {
+			t.Fatal("Unpad accepted corrupted padding")
+		}
+	})
+
+	t.Run("inconsistent padding bytes", func(t *testing.T) {
+		data := bytes.Repeat([]byte{0x03}, blockSize+1)
+		padded := Pad(append([]byte(nil), data...), blockSize)
+		// Flip one padding byte in the middle of the run so the length
+		// byte still looks plausible but the run itself is inconsistent.
+		padded[len(padded)-2] ^= 0x01
+		if _, err := Unpad(padded, blockSize); err == nil {
+			t.Fatal("Unpad accepted inconsistent padding bytes")
+		}
+	})
+
+	t.Run("length not a multiple of block size", func(t *testing.T) {
+		if _, err := Unpad(bytes.Repeat([]byte{0x10}, blockSize+1), blockSize); err == nil {
+			t.Fatal("Unpad accepted a non-block-aligned length")
+		}
+	})
+
+	t.Run("does not write through to the input's spare capacity", func(t *testing.T) {
+		backing := make([]byte, blockSize*2)
+		for i := range backing {
+			backing[i] = 0xee
+		}
+		data := backing[:blockSize]
+		_ = Pad(data, blockSize)
+		for i := blockSize; i < len(backing); i++ {
+			if backing[i] != 0xee {
+				t.Fatalf("Pad clobbered byte %d of the caller's backing array: got %#x, want 0xee", i, backing[i])
+			}
+		}
+	})
+}
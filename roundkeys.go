@@ -0,0 +1,14 @@
+package lea
+
+// flatRoundKeys expands K into a contiguous slice of 6*Nr round-key
+// words for the given mode. The block-level hot path indexes into this
+// slice directly instead of walking the [][6]word rows RoundKey returns,
+// avoiding a slice-header dereference per round.
+func flatRoundKeys(K []byte, mode int) []word {
+	rows := RoundKey(K, mode)
+	flat := make([]word, 0, len(rows)*6)
+	for _, row := range rows {
+		flat = append(flat, row[:]...)
+	}
+	return flat
+}
@@ -0,0 +1,181 @@
+// Package xts implements XTS-LEA, the XEX-based tweaked-codebook mode
+// with ciphertext stealing used for full-disk encryption, layered on
+// LEA the same way x/crypto/xts layers XTS on AES.
+package xts
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+
+	"github.com/jukworks/lea"
+)
+
+const blockSize = 16
+
+// Cipher encrypts or decrypts a sector at a time using XTS-LEA.
+type Cipher struct {
+	k1, k2 cipher.Block
+}
+
+// NewCipher creates a Cipher for XTS-LEA. key must be 32 or 64 bytes:
+// two concatenated LEA-128 keys, or two concatenated LEA-256 keys. The
+// first half encrypts data blocks; the second half derives the tweak.
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != 32 && len(key) != 64 {
+		return nil, errors.New("xts: key must be 32 or 64 bytes (two LEA-128 keys or two LEA-256 keys)")
+	}
+	half := len(key) / 2
+	k1, err := lea.NewCipher(key[:half])
+	if err != nil {
+		return nil, err
+	}
+	k2, err := lea.NewCipher(key[half:])
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{k1: k1, k2: k2}, nil
+}
+
+// sectorTweak encrypts the little-endian sector number with k2 to
+// derive the initial tweak for that sector.
+func (c *Cipher) sectorTweak(sectorNum uint64) (tweak [blockSize]byte) {
+	var buf [blockSize]byte
+	binary.LittleEndian.PutUint64(buf[:8], sectorNum)
+	c.k2.Encrypt(tweak[:], buf[:])
+	return tweak
+}
+
+// mulAlpha multiplies tweak by alpha (x) in GF(2^128) with reduction
+// polynomial x^128 + x^7 + x^2 + x + 1, per the XTS tweak update.
+func mulAlpha(tweak *[blockSize]byte) {
+	var carry byte
+	for i := range tweak {
+		next := tweak[i] >> 7
+		tweak[i] = (tweak[i] << 1) | carry
+		carry = next
+	}
+	if carry != 0 {
+		tweak[0] ^= 0x87
+	}
+}
+
+func xorBlock(dst, a, b []byte) {
+	for i := 0; i < blockSize; i++ {
+		dst[i] = a[i] ^ b[i]
+	}
+}
+
+// xexEncrypt computes Encrypt_k1(src XOR tweak) XOR tweak.
+func (c *Cipher) xexEncrypt(dst, src []byte, tweak *[blockSize]byte) {
+	var block [blockSize]byte
+	xorBlock(block[:], src, tweak[:])
+	c.k1.Encrypt(block[:], block[:])
+	xorBlock(dst, block[:], tweak[:])
+}
+
+// xexDecrypt computes Decrypt_k1(src XOR tweak) XOR tweak.
+func (c *Cipher) xexDecrypt(dst, src []byte, tweak *[blockSize]byte) {
+	var block [blockSize]byte
+	xorBlock(block[:], src, tweak[:])
+	c.k1.Decrypt(block[:], block[:])
+	xorBlock(dst, block[:], tweak[:])
+}
+
+// Encrypt encrypts a single sector of at least blockSize bytes from src
+// into dst, applying ciphertext stealing when len(src) is not a
+// multiple of blockSize.
+func (c *Cipher) Encrypt(dst, src []byte, sectorNum uint64) {
+	if len(src) < blockSize {
+		panic("xts: sector is smaller than the cipher's block size")
+	}
+	if len(dst) < len(src) {
+		panic("xts: destination is smaller than source")
+	}
+
+	tweak := c.sectorTweak(sectorNum)
+	numBlocks := len(src) / blockSize
+	remain := len(src) % blockSize
+
+	full := numBlocks
+	if remain != 0 {
+		full--
+	}
+	for i := 0; i < full; i++ {
+		off := i * blockSize
+		c.xexEncrypt(dst[off:off+blockSize], src[off:off+blockSize], &tweak)
+		mulAlpha(&tweak)
+	}
+
+	if remain == 0 {
+		return
+	}
+
+	// Ciphertext stealing: encrypt the last full plaintext block with the
+	// current tweak, truncate its tail into the final output block, and
+	// fold that stolen tail plus the final partial plaintext into a
+	// combined block encrypted with the next tweak for the penultimate
+	// output position.
+	penultimateOff := full * blockSize
+	finalOff := (full + 1) * blockSize
+
+	var cc [blockSize]byte
+	c.xexEncrypt(cc[:], src[penultimateOff:penultimateOff+blockSize], &tweak)
+	copy(dst[finalOff:], cc[:remain])
+
+	var combined [blockSize]byte
+	copy(combined[:remain], src[finalOff:])
+	copy(combined[remain:], cc[remain:])
+
+	nextTweak := tweak
+	mulAlpha(&nextTweak)
+	c.xexEncrypt(dst[penultimateOff:penultimateOff+blockSize], combined[:], &nextTweak)
+}
+
+// Decrypt decrypts a single sector produced by Encrypt.
+func (c *Cipher) Decrypt(dst, src []byte, sectorNum uint64) {
+	if len(src) < blockSize {
+		panic("xts: sector is smaller than the cipher's block size")
+	}
+	if len(dst) < len(src) {
+		panic("xts: destination is smaller than source")
+	}
+
+	tweak := c.sectorTweak(sectorNum)
+	numBlocks := len(src) / blockSize
+	remain := len(src) % blockSize
+
+	full := numBlocks
+	if remain != 0 {
+		full--
+	}
+	for i := 0; i < full; i++ {
+		off := i * blockSize
+		c.xexDecrypt(dst[off:off+blockSize], src[off:off+blockSize], &tweak)
+		mulAlpha(&tweak)
+	}
+
+	if remain == 0 {
+		return
+	}
+
+	penultimateOff := full * blockSize
+	finalOff := (full + 1) * blockSize
+
+	nextTweak := tweak
+	mulAlpha(&nextTweak)
+
+	// Decrypting the combined block with T_m recovers the final partial
+	// plaintext in its first `remain` bytes and the stolen tail of the
+	// penultimate ciphertext block in the rest.
+	var scratch [blockSize]byte
+	c.xexDecrypt(scratch[:], src[penultimateOff:penultimateOff+blockSize], &nextTweak)
+
+	copy(dst[finalOff:], scratch[:remain])
+
+	var penultimateCipher [blockSize]byte
+	copy(penultimateCipher[:remain], src[finalOff:])
+	copy(penultimateCipher[remain:], scratch[remain:])
+
+	c.xexDecrypt(dst[penultimateOff:penultimateOff+blockSize], penultimateCipher[:], &tweak)
+}
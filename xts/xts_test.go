@@ -0,0 +1,98 @@
+package xts
+
+import (
+	"bytes"
+	"testing"
+)
+
+func keyForSize(size int) []byte {
+	k := make([]byte, size)
+	for i := range k {
+		k[i] = byte(i*11 + size)
+	}
+	return k
+}
+
+// TestNewCipherKeySize checks that NewCipher accepts only the two valid
+// XTS-LEA key lengths and rejects everything else, including an even
+// length (two LEA-192 halves) that isn't one of the two specified sizes.
+func TestNewCipherKeySize(t *testing.T) {
+	for _, size := range []int{32, 64} {
+		if _, err := NewCipher(keyForSize(size)); err != nil {
+			t.Errorf("NewCipher(%d bytes): %v", size, err)
+		}
+	}
+	for _, size := range []int{0, 16, 31, 48, 65} {
+		if _, err := NewCipher(keyForSize(size)); err == nil {
+			t.Errorf("NewCipher(%d bytes): want error, got nil", size)
+		}
+	}
+}
+
+// TestRoundTrip exercises Encrypt/Decrypt across sector lengths that span
+// a single partial block, several full blocks, and every length just
+// above/below a block boundary, so the ciphertext-stealing branch
+// (lengths not a multiple of blockSize) is covered alongside the plain
+// full-block path.
+func TestRoundTrip(t *testing.T) {
+	for _, keySize := range []int{32, 64} {
+		key := keyForSize(keySize)
+		c, err := NewCipher(key)
+		if err != nil {
+			t.Fatalf("NewCipher(keySize=%d): %v", keySize, err)
+		}
+		for length := blockSize; length <= 40; length++ {
+			pt := make([]byte, length)
+			for i := range pt {
+				pt[i] = byte(i*7 + length)
+			}
+			ct := make([]byte, length)
+			c.Encrypt(ct, pt, 5)
+
+			got := make([]byte, length)
+			c.Decrypt(got, ct, 5)
+			if !bytes.Equal(got, pt) {
+				t.Fatalf("keySize=%d length=%d: round trip = %x, want %x", keySize, length, got, pt)
+			}
+		}
+	}
+}
+
+// TestSectorNumberAffectsCiphertext checks that the same plaintext
+// encrypted under different sector numbers produces different
+// ciphertext, for both a single-block sector and a sector that exercises
+// ciphertext stealing.
+func TestSectorNumberAffectsCiphertext(t *testing.T) {
+	c, err := NewCipher(keyForSize(32))
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	for _, length := range []int{blockSize, blockSize + 5} {
+		pt := bytes.Repeat([]byte{0x5a}, length)
+
+		ct1 := make([]byte, length)
+		c.Encrypt(ct1, pt, 1)
+		ct2 := make([]byte, length)
+		c.Encrypt(ct2, pt, 2)
+
+		if bytes.Equal(ct1, ct2) {
+			t.Fatalf("length=%d: ciphertext identical for different sector numbers", length)
+		}
+	}
+}
+
+// TestEncryptRejectsShortSector checks that a sector shorter than a
+// single block is rejected rather than silently mishandled.
+func TestEncryptRejectsShortSector(t *testing.T) {
+	c, err := NewCipher(keyForSize(32))
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Encrypt did not panic on a sector shorter than one block")
+		}
+	}()
+	dst := make([]byte, blockSize-1)
+	c.Encrypt(dst, dst, 0)
+}